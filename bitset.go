@@ -10,7 +10,10 @@
 package bitset
 
 import (
+	"encoding/binary"
 	"errors"
+	"iter"
+	"math/bits"
 	"unsafe"
 )
 
@@ -53,50 +56,72 @@ type BitSet interface {
 	Bytes() []byte
 }
 
+// BitOrder selects how bit positions map onto the bits of each byte in a ByteBitSet.
+type BitOrder uint8
+
+const (
+	// MSBFirst stores bit 0 in the most significant bit of the first byte. This is the package default.
+	MSBFirst BitOrder = iota
+	// LSBFirst stores bit 0 in the least significant bit of the first byte.
+	LSBFirst
+)
+
+// pickOrder returns the first element of order, or MSBFirst if order is empty.
+func pickOrder(order []BitOrder) BitOrder {
+	if len(order) > 0 {
+		return order[0]
+	}
+	return MSBFirst
+}
+
 // ByteBitSet is a BitSet implementation that stores bits in a byte slice.
 // Each bit is represented by a single bit in the byte array, starting from the most significant bit of the first byte.
 type ByteBitSet struct {
-	mask []uint8
+	mask  []uint8
+	order BitOrder
 }
 
 var _ BitSet = (*ByteBitSet)(nil)
 
 // New returns a new ByteBitSet with enough space to store the specified number of bits.
-func New(size int) ByteBitSet {
+// An optional BitOrder selects the bit layout within each byte; it defaults to MSBFirst.
+func New(size int, order ...BitOrder) ByteBitSet {
 	n := size / 8
 	if size%8 > 0 {
 		n++
 	}
-	return ByteBitSet{mask: make([]uint8, n)}
+	return ByteBitSet{mask: make([]uint8, n), order: pickOrder(order)}
 }
 
 // ParseHexString creates a ByteBitSet from a hexadecimal string representation.
 // Returns an error if the input string is invalid.
-func ParseHexString(hexStr string) (ByteBitSet, error) {
+// An optional BitOrder selects the bit layout within each byte; it defaults to MSBFirst.
+func ParseHexString(hexStr string, order ...BitOrder) (ByteBitSet, error) {
 
 	if len(hexStr) != len([]rune(hexStr)) {
 		return ByteBitSet{}, ErrInvalidSourceString
 	}
 	buf := unsafe.Slice(unsafe.StringData(hexStr), len(hexStr))
-	return parseHexBytes(buf)
+	return parseHexBytes(buf, pickOrder(order))
 }
 
 // ParseHexBytes is a sugar function that creates a ByteBitSet from a byte slice.
-func ParseHexBytes(hexStr []byte) (ByteBitSet, error) {
-	return parseHexBytes(hexStr)
+// An optional BitOrder selects the bit layout within each byte; it defaults to MSBFirst.
+func ParseHexBytes(hexStr []byte, order ...BitOrder) (ByteBitSet, error) {
+	return parseHexBytes(hexStr, pickOrder(order))
 }
 
-func parseHexBytes(src []byte) (ByteBitSet, error) {
+func parseHexBytes(src []byte, order BitOrder) (ByteBitSet, error) {
 
 	if len(src) == 0 {
-		return ByteBitSet{}, nil
+		return ByteBitSet{order: order}, nil
 	}
 
 	if len(src)%2 == 1 {
 		return ByteBitSet{}, ErrInvalidSourceString
 	}
 
-	bbs := New(len(src) / 2 * 8)
+	bbs := New(len(src)/2*8, order)
 
 	for i := 0; i < len(src); i += 2 {
 		bm, err := parsePair(src[i], src[i+1])
@@ -110,13 +135,14 @@ func parseHexBytes(src []byte) (ByteBitSet, error) {
 
 // ParseBinaryString creates a BitSet from a binary string of '0' and '1' characters.
 // Returns an error if any characters other than '0' or '1' are found.
-func ParseBinaryString(src string) (ByteBitSet, error) {
+// An optional BitOrder selects the bit layout within each byte; it defaults to MSBFirst.
+func ParseBinaryString(src string, order ...BitOrder) (ByteBitSet, error) {
 
 	if len(src) == 0 {
-		return ByteBitSet{}, nil
+		return ByteBitSet{order: pickOrder(order)}, nil
 	}
 
-	bbs := New(len(src))
+	bbs := New(len(src), order...)
 
 	for i, c := range []rune(src) {
 		if !(c == '0' || c == '1') {
@@ -130,7 +156,8 @@ func ParseBinaryString(src string) (ByteBitSet, error) {
 // Clone returns a deep copy of the provided ByteBitSet.
 func Clone(src ByteBitSet) ByteBitSet {
 	dst := ByteBitSet{
-		mask: make([]uint8, len(src.mask)),
+		mask:  make([]uint8, len(src.mask)),
+		order: src.order,
 	}
 	copy(dst.mask, src.mask)
 	return dst
@@ -150,7 +177,7 @@ func (bbs *ByteBitSet) set(val bool, bit uint) {
 	//bn := bit / 8
 	//bitn := uint8(bit % 8)
 	size := uint(len(bbs.mask))
-	bn, bitn := offsets(bit)
+	bn, bitn := offsets(bit, bbs.order)
 
 	// Extend internal storage if needed
 	switch {
@@ -176,7 +203,7 @@ func (bbs ByteBitSet) IsSet(bit uint) bool {
 		return false
 	}
 
-	bn, bitn := offsets(bit)
+	bn, bitn := offsets(bit, bbs.order)
 	return (uint(bbs.mask[bn]))>>bitn&1 == 1
 }
 
@@ -223,6 +250,18 @@ func (bbs ByteBitSet) Bytes() []byte {
 	return bbs.mask
 }
 
+// AsUint64s returns an LSB-first packed view of the underlying bytes as uint64 words,
+// suitable for fast interop with math/bits popcount routines and other bitset libraries.
+// Unlike BitOrder, which controls bit addressing, this packing is always little-endian byte order.
+func (bbs ByteBitSet) AsUint64s() []uint64 {
+	words := (len(bbs.mask) + 7) / 8
+	result := make([]uint64, words)
+	for i, b := range bbs.mask {
+		result[i/8] |= uint64(b) << (8 * (i % 8))
+	}
+	return result
+}
+
 // String returns the hexadecimal string representation of the bitset.
 func (bbs ByteBitSet) String() string {
 	if len(bbs.mask) == 0 {
@@ -303,8 +342,15 @@ func Validate(buf []byte) error {
 }
 
 // AreSet evaluates whether all or any specified bits are set, based on the rule,
-// using a hexadecimal string representation of the bitset.
+// using a hexadecimal string representation of the bitset assuming MSBFirst bit order.
+// Use AreSetWithOrder to evaluate a string encoded with a different BitOrder.
 func AreSet(hexStr string, rule CompareRule, bits ...uint) (bool, error) {
+	return AreSetWithOrder(hexStr, rule, MSBFirst, bits...)
+}
+
+// AreSetWithOrder evaluates whether all or any specified bits are set, based on the rule,
+// using a hexadecimal string representation of the bitset interpreted with the given BitOrder.
+func AreSetWithOrder(hexStr string, rule CompareRule, order BitOrder, bits ...uint) (bool, error) {
 
 	n := len(hexStr)
 	if n == 0 || len(bits) == 0 {
@@ -327,7 +373,7 @@ func AreSet(hexStr string, rule CompareRule, bits ...uint) (bool, error) {
 			continue
 		}
 
-		bn, bitn := offsets(bit)
+		bn, bitn := offsets(bit, order)
 		byteVal, err := parsePair(buf[bn*2], buf[bn*2+1])
 		if err != nil {
 			return false, err
@@ -351,6 +397,256 @@ func AreSet(hexStr string, rule CompareRule, bits ...uint) (bool, error) {
 	return false, nil
 }
 
-func offsets(bit uint) (uint, uint) {
+// offsets decomposes bit into a byte index and an in-byte shift amount according to order.
+func offsets(bit uint, order BitOrder) (uint, uint) {
+	if order == LSBFirst {
+		return bit / 8, bit % 8
+	}
 	return bit / 8, 7 - bit%8
 }
+
+// growTo extends mask with zero bytes so that it is at least n bytes long.
+// It is a no-op if mask is already long enough.
+func (bbs *ByteBitSet) growTo(n int) {
+	if n > len(bbs.mask) {
+		bbs.mask = append(bbs.mask, make([]byte, n-len(bbs.mask))...)
+	}
+}
+
+// Union returns a new ByteBitSet holding the bitwise OR of a and b.
+// The shorter operand's missing bytes are treated as zero.
+func Union(a, b ByteBitSet) ByteBitSet {
+	dst := Clone(a)
+	dst.UnionInPlace(b)
+	return dst
+}
+
+// Intersection returns a new ByteBitSet holding the bitwise AND of a and b.
+// The shorter operand's missing bytes are treated as zero.
+func Intersection(a, b ByteBitSet) ByteBitSet {
+	dst := Clone(a)
+	dst.IntersectionInPlace(b)
+	return dst
+}
+
+// Difference returns a new ByteBitSet holding the bits set in a but not in b.
+// The shorter operand's missing bytes are treated as zero.
+func Difference(a, b ByteBitSet) ByteBitSet {
+	dst := Clone(a)
+	dst.DifferenceInPlace(b)
+	return dst
+}
+
+// SymmetricDifference returns a new ByteBitSet holding the bitwise XOR of a and b.
+// The shorter operand's missing bytes are treated as zero.
+func SymmetricDifference(a, b ByteBitSet) ByteBitSet {
+	dst := Clone(a)
+	dst.SymmetricDifferenceInPlace(b)
+	return dst
+}
+
+// Complement returns a new ByteBitSet with every bit of a flipped.
+// The result has the same length as a.
+func Complement(a ByteBitSet) ByteBitSet {
+	dst := Clone(a)
+	dst.ComplementInPlace()
+	return dst
+}
+
+// UnionInPlace sets every bit in bbs that is set in other, growing bbs if other is longer.
+func (bbs *ByteBitSet) UnionInPlace(other ByteBitSet) {
+	bbs.growTo(len(other.mask))
+	for i := range other.mask {
+		bbs.mask[i] |= other.mask[i]
+	}
+}
+
+// IntersectionInPlace clears every bit in bbs that is not set in other.
+// Bytes beyond the end of other are treated as zero and cleared.
+func (bbs *ByteBitSet) IntersectionInPlace(other ByteBitSet) {
+	for i := range bbs.mask {
+		if i < len(other.mask) {
+			bbs.mask[i] &= other.mask[i]
+		} else {
+			bbs.mask[i] = 0
+		}
+	}
+}
+
+// DifferenceInPlace clears every bit in bbs that is set in other.
+func (bbs *ByteBitSet) DifferenceInPlace(other ByteBitSet) {
+	n := len(other.mask)
+	if n > len(bbs.mask) {
+		n = len(bbs.mask)
+	}
+	for i := 0; i < n; i++ {
+		bbs.mask[i] &^= other.mask[i]
+	}
+}
+
+// SymmetricDifferenceInPlace toggles every bit in bbs that is set in other, growing bbs if other is longer.
+func (bbs *ByteBitSet) SymmetricDifferenceInPlace(other ByteBitSet) {
+	bbs.growTo(len(other.mask))
+	for i := range other.mask {
+		bbs.mask[i] ^= other.mask[i]
+	}
+}
+
+// ComplementInPlace flips every bit currently allocated in bbs.
+func (bbs *ByteBitSet) ComplementInPlace() {
+	for i := range bbs.mask {
+		bbs.mask[i] = ^bbs.mask[i]
+	}
+}
+
+// Count returns the number of bits set to 1, also known as the population count or Hamming weight.
+// It processes mask a uint64 word at a time for speed, reading each word with
+// binary.LittleEndian so it does not depend on the backing array being 8-byte aligned.
+func (bbs ByteBitSet) Count() uint {
+	n := len(bbs.mask)
+	words := n / 8
+	var count int
+	for i := 0; i < words; i++ {
+		count += bits.OnesCount64(binary.LittleEndian.Uint64(bbs.mask[i*8:]))
+	}
+	for i := words * 8; i < n; i++ {
+		count += bits.OnesCount8(bbs.mask[i])
+	}
+	return uint(count)
+}
+
+// Equal reports whether a and b have the same bits set.
+// The shorter operand's missing bytes are treated as zero, so two bitsets
+// of different lengths can still be equal.
+// The comparison is byte by byte and ignores BitOrder, so a and b built with
+// different BitOrders are compared by their raw bytes, not by bit position.
+func Equal(a, b ByteBitSet) bool {
+	n := len(a.mask)
+	if len(b.mask) > n {
+		n = len(b.mask)
+	}
+	for i := 0; i < n; i++ {
+		var av, bv byte
+		if i < len(a.mask) {
+			av = a.mask[i]
+		}
+		if i < len(b.mask) {
+			bv = b.mask[i]
+		}
+		if av != bv {
+			return false
+		}
+	}
+	return true
+}
+
+// NextSet returns the position of the first set bit at or after from, and true if one was found.
+// It skips whole zero bytes and uses LeadingZeros8 on the remaining non-zero byte.
+// It assumes MSBFirst bit order; results are undefined for a ByteBitSet built with LSBFirst.
+func (bbs ByteBitSet) NextSet(from uint) (uint, bool) {
+	n := uint(len(bbs.mask))
+	if from >= n*8 {
+		return 0, false
+	}
+
+	bn := from / 8
+	startBitn := 7 - from%8
+	if b := bbs.mask[bn] & (uint8(0xFF) >> (7 - startBitn)); b != 0 {
+		return bn*8 + uint(bits.LeadingZeros8(b)), true
+	}
+
+	for i := bn + 1; i < n; i++ {
+		if bbs.mask[i] != 0 {
+			return i*8 + uint(bits.LeadingZeros8(bbs.mask[i])), true
+		}
+	}
+	return 0, false
+}
+
+// PrevSet returns the position of the last set bit at or before from, and true if one was found.
+// It skips whole zero bytes and uses TrailingZeros8 on the remaining non-zero byte.
+// It assumes MSBFirst bit order; results are undefined for a ByteBitSet built with LSBFirst.
+func (bbs ByteBitSet) PrevSet(from uint) (uint, bool) {
+	n := uint(len(bbs.mask))
+	if n == 0 {
+		return 0, false
+	}
+	if from >= n*8 {
+		from = n*8 - 1
+	}
+
+	bn := from / 8
+	startBitn := 7 - from%8
+	if b := bbs.mask[bn] & (uint8(0xFF) << startBitn); b != 0 {
+		return bn*8 + 7 - uint(bits.TrailingZeros8(b)), true
+	}
+
+	for i := int(bn) - 1; i >= 0; i-- {
+		if bbs.mask[i] != 0 {
+			return uint(i)*8 + 7 - uint(bits.TrailingZeros8(bbs.mask[i])), true
+		}
+	}
+	return 0, false
+}
+
+// All returns a range-over-func iterator over the positions of every set bit, in increasing order.
+// Unlike NextSet and PrevSet, it honors the receiver's BitOrder.
+func (bbs ByteBitSet) All() iter.Seq[uint] {
+	next := bbs.NextSet
+	if bbs.order == LSBFirst {
+		next = bbs.nextSetLSBFirst
+	}
+	return func(yield func(uint) bool) {
+		for pos, ok := next(0); ok; pos, ok = next(pos + 1) {
+			if !yield(pos) {
+				return
+			}
+		}
+	}
+}
+
+// nextSetLSBFirst is the LSBFirst counterpart to NextSet: within each byte,
+// bit 0 is the least significant bit, so it scans via TrailingZeros8 instead.
+func (bbs ByteBitSet) nextSetLSBFirst(from uint) (uint, bool) {
+	n := uint(len(bbs.mask))
+	if from >= n*8 {
+		return 0, false
+	}
+
+	bn := from / 8
+	startBitn := from % 8
+	if b := bbs.mask[bn] & (uint8(0xFF) << startBitn); b != 0 {
+		return bn*8 + uint(bits.TrailingZeros8(b)), true
+	}
+
+	for i := bn + 1; i < n; i++ {
+		if bbs.mask[i] != 0 {
+			return i*8 + uint(bits.TrailingZeros8(bbs.mask[i])), true
+		}
+	}
+	return 0, false
+}
+
+// SetBits returns a newly allocated slice holding the positions of every set bit, in increasing order.
+func (bbs ByteBitSet) SetBits() []uint {
+	positions := make([]uint, 0, bbs.Count())
+	for pos := range bbs.All() {
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// IsSubsetOf reports whether every bit set in bbs is also set in other.
+// Like Equal, it compares raw bytes and ignores BitOrder.
+func (bbs ByteBitSet) IsSubsetOf(other ByteBitSet) bool {
+	for i, v := range bbs.mask {
+		var ov byte
+		if i < len(other.mask) {
+			ov = other.mask[i]
+		}
+		if v&^ov != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -390,6 +390,375 @@ func TestParseString(t *testing.T) {
 	})
 }
 
+func TestUnion(t *testing.T) {
+	a, _ := ParseHexString("f0")
+	b, _ := ParseHexString("0f")
+	dst := Union(a, b)
+	if dst.String() != "ff" {
+		t.Errorf("expected ff, got %s", dst.String())
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	a, _ := ParseHexString("ff")
+	b, _ := ParseHexString("0f")
+	dst := Intersection(a, b)
+	if dst.String() != "0f" {
+		t.Errorf("expected 0f, got %s", dst.String())
+	}
+
+	t.Run("shorter operand treated as zero", func(t *testing.T) {
+		a, _ := ParseHexString("ffff")
+		b, _ := ParseHexString("ff")
+		dst := Intersection(a, b)
+		if dst.String() != "ff00" {
+			t.Errorf("expected ff00, got %s", dst.String())
+		}
+	})
+}
+
+func TestDifference(t *testing.T) {
+	a, _ := ParseHexString("ff")
+	b, _ := ParseHexString("0f")
+	dst := Difference(a, b)
+	if dst.String() != "f0" {
+		t.Errorf("expected f0, got %s", dst.String())
+	}
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	a, _ := ParseHexString("ff")
+	b, _ := ParseHexString("0f")
+	dst := SymmetricDifference(a, b)
+	if dst.String() != "f0" {
+		t.Errorf("expected f0, got %s", dst.String())
+	}
+}
+
+func TestComplement(t *testing.T) {
+	a, _ := ParseHexString("0f")
+	dst := Complement(a)
+	if dst.String() != "f0" {
+		t.Errorf("expected f0, got %s", dst.String())
+	}
+}
+
+func TestInPlaceMutators(t *testing.T) {
+	t.Run("UnionInPlace grows receiver", func(t *testing.T) {
+		a, _ := ParseHexString("ff")
+		b, _ := ParseHexString("ff01")
+		a.UnionInPlace(b)
+		if a.String() != "ff01" {
+			t.Errorf("expected ff01, got %s", a.String())
+		}
+	})
+
+	t.Run("IntersectionInPlace", func(t *testing.T) {
+		a, _ := ParseHexString("ff")
+		b, _ := ParseHexString("0f")
+		a.IntersectionInPlace(b)
+		if a.String() != "0f" {
+			t.Errorf("expected 0f, got %s", a.String())
+		}
+	})
+
+	t.Run("DifferenceInPlace", func(t *testing.T) {
+		a, _ := ParseHexString("ff")
+		b, _ := ParseHexString("0f")
+		a.DifferenceInPlace(b)
+		if a.String() != "f0" {
+			t.Errorf("expected f0, got %s", a.String())
+		}
+	})
+
+	t.Run("SymmetricDifferenceInPlace", func(t *testing.T) {
+		a, _ := ParseHexString("ff")
+		b, _ := ParseHexString("0f")
+		a.SymmetricDifferenceInPlace(b)
+		if a.String() != "f0" {
+			t.Errorf("expected f0, got %s", a.String())
+		}
+	})
+
+	t.Run("ComplementInPlace", func(t *testing.T) {
+		a, _ := ParseHexString("0f")
+		a.ComplementInPlace()
+		if a.String() != "f0" {
+			t.Errorf("expected f0, got %s", a.String())
+		}
+	})
+}
+
+func TestCount(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		bs := New(0)
+		if n := bs.Count(); n != 0 {
+			t.Errorf("expected 0, got %d", n)
+		}
+	})
+
+	t.Run("single byte", func(t *testing.T) {
+		bs, _ := ParseHexString("b3")
+		if n := bs.Count(); n != 5 {
+			t.Errorf("expected 5, got %d", n)
+		}
+	})
+
+	t.Run("spans multiple words", func(t *testing.T) {
+		bs, _ := ParseHexString("ffffffffffffffffff")
+		if n := bs.Count(); n != 72 {
+			t.Errorf("expected 72, got %d", n)
+		}
+	})
+}
+
+func TestEqual(t *testing.T) {
+	t.Run("equal same length", func(t *testing.T) {
+		a, _ := ParseHexString("b3")
+		b, _ := ParseHexString("b3")
+		if !Equal(a, b) {
+			t.Error("expected bitsets to be equal")
+		}
+	})
+
+	t.Run("equal different length with trailing zeros", func(t *testing.T) {
+		a, _ := ParseHexString("b3")
+		b, _ := ParseHexString("b300")
+		if !Equal(a, b) {
+			t.Error("expected bitsets to be equal")
+		}
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		a, _ := ParseHexString("b3")
+		b, _ := ParseHexString("b2")
+		if Equal(a, b) {
+			t.Error("expected bitsets to differ")
+		}
+	})
+}
+
+func TestIsSubsetOf(t *testing.T) {
+	t.Run("subset", func(t *testing.T) {
+		a, _ := ParseHexString("0f")
+		b, _ := ParseHexString("ff")
+		if !a.IsSubsetOf(b) {
+			t.Error("expected a to be a subset of b")
+		}
+	})
+
+	t.Run("not a subset", func(t *testing.T) {
+		a, _ := ParseHexString("f0")
+		b, _ := ParseHexString("0f")
+		if a.IsSubsetOf(b) {
+			t.Error("expected a not to be a subset of b")
+		}
+	})
+}
+
+func TestByteBitSet_NextSet(t *testing.T) {
+	bs, _ := ParseHexString("80010001")
+	// bits 0, 15, 31 are set.
+
+	t.Run("from start", func(t *testing.T) {
+		pos, ok := bs.NextSet(0)
+		if !ok || pos != 0 {
+			t.Errorf("expected (0, true), got (%d, %v)", pos, ok)
+		}
+	})
+
+	t.Run("skips the rest of the current byte", func(t *testing.T) {
+		pos, ok := bs.NextSet(1)
+		if !ok || pos != 15 {
+			t.Errorf("expected (15, true), got (%d, %v)", pos, ok)
+		}
+	})
+
+	t.Run("finds the last bit", func(t *testing.T) {
+		pos, ok := bs.NextSet(16)
+		if !ok || pos != 31 {
+			t.Errorf("expected (31, true), got (%d, %v)", pos, ok)
+		}
+	})
+
+	t.Run("none left", func(t *testing.T) {
+		_, ok := bs.NextSet(32)
+		if ok {
+			t.Error("expected no more set bits")
+		}
+	})
+}
+
+func TestByteBitSet_PrevSet(t *testing.T) {
+	bs, _ := ParseHexString("80010001")
+	// bits 0, 15, 31 are set.
+
+	t.Run("from end", func(t *testing.T) {
+		pos, ok := bs.PrevSet(31)
+		if !ok || pos != 31 {
+			t.Errorf("expected (31, true), got (%d, %v)", pos, ok)
+		}
+	})
+
+	t.Run("skips a zero byte", func(t *testing.T) {
+		pos, ok := bs.PrevSet(30)
+		if !ok || pos != 15 {
+			t.Errorf("expected (15, true), got (%d, %v)", pos, ok)
+		}
+	})
+
+	t.Run("finds the first bit", func(t *testing.T) {
+		pos, ok := bs.PrevSet(14)
+		if !ok || pos != 0 {
+			t.Errorf("expected (0, true), got (%d, %v)", pos, ok)
+		}
+	})
+
+	t.Run("finds bit at from itself", func(t *testing.T) {
+		pos, ok := bs.PrevSet(0)
+		if !ok || pos != 0 {
+			t.Errorf("expected (0, true), got (%d, %v)", pos, ok)
+		}
+	})
+
+	t.Run("none left", func(t *testing.T) {
+		empty, _ := ParseHexString("00")
+		_, ok := empty.PrevSet(7)
+		if ok {
+			t.Error("expected no set bits")
+		}
+	})
+}
+
+func TestByteBitSet_All(t *testing.T) {
+	bs, _ := ParseHexString("80010001")
+	var got []uint
+	for pos := range bs.All() {
+		got = append(got, pos)
+	}
+
+	want := []uint{0, 15, 31}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestByteBitSet_SetBits(t *testing.T) {
+	bs, _ := ParseHexString("80010001")
+	got := bs.SetBits()
+
+	want := []uint{0, 15, 31}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestByteBitSet_LSBFirst(t *testing.T) {
+	t.Run("New with LSBFirst", func(t *testing.T) {
+		bs := New(8, LSBFirst)
+		bs.Set(true, 0, 7)
+		if !bs.IsSet(0) || !bs.IsSet(7) {
+			t.Error("expected bits 0 and 7 to be set")
+		}
+		if bs.Bytes()[0] != 0b10000001 {
+			t.Errorf("expected byte 0x81, got %#b", bs.Bytes()[0])
+		}
+	})
+
+	t.Run("ParseBinaryString with LSBFirst", func(t *testing.T) {
+		bs, err := ParseBinaryString("10000000", LSBFirst)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bs.IsSet(0) {
+			t.Error("expected bit 0 to be set")
+		}
+		if bs.IsSet(7) {
+			t.Error("expected bit 7 to be unset")
+		}
+	})
+
+	t.Run("ParseHexString is unaffected by order, only bit addressing changes", func(t *testing.T) {
+		msb, _ := ParseHexString("80")
+		lsb, _ := ParseHexString("80", LSBFirst)
+		if msb.String() != lsb.String() {
+			t.Errorf("expected same hex representation, got %s and %s", msb.String(), lsb.String())
+		}
+		if !msb.IsSet(0) {
+			t.Error("expected MSBFirst bit 0 to be set")
+		}
+		if !lsb.IsSet(7) {
+			t.Error("expected LSBFirst bit 7 to be set")
+		}
+	})
+
+	t.Run("AreSetWithOrder", func(t *testing.T) {
+		res, err := AreSetWithOrder("80", All, LSBFirst, 7)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !res {
+			t.Error("expected bit 7 to be set under LSBFirst")
+		}
+	})
+
+	t.Run("All honors LSBFirst", func(t *testing.T) {
+		bs, _ := ParseHexString("80010001", LSBFirst)
+		var got []uint
+		for pos := range bs.All() {
+			got = append(got, pos)
+		}
+
+		want := []uint{7, 8, 24}
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("expected %v, got %v", want, got)
+				break
+			}
+		}
+	})
+}
+
+func TestByteBitSet_AsUint64s(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		bs := New(0)
+		if words := bs.AsUint64s(); len(words) != 0 {
+			t.Errorf("expected no words, got %v", words)
+		}
+	})
+
+	t.Run("single word", func(t *testing.T) {
+		bs, _ := ParseHexString("0100000000000000")
+		words := bs.AsUint64s()
+		if len(words) != 1 || words[0] != 1 {
+			t.Errorf("expected [1], got %v", words)
+		}
+	})
+
+	t.Run("partial trailing word", func(t *testing.T) {
+		bs, _ := ParseHexString("01")
+		words := bs.AsUint64s()
+		if len(words) != 1 || words[0] != 1 {
+			t.Errorf("expected [1], got %v", words)
+		}
+	})
+}
+
 func BenchmarkByteBitSet_IsSet(b *testing.B) {
 	bs := New(1000)
 	bs.Set(true, 500) // Set a single bit for testing
@@ -0,0 +1,129 @@
+package bitset
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*ByteBitSet)(nil)
+	_ encoding.BinaryUnmarshaler = (*ByteBitSet)(nil)
+	_ encoding.TextMarshaler     = (*ByteBitSet)(nil)
+	_ encoding.TextUnmarshaler   = (*ByteBitSet)(nil)
+	_ json.Marshaler             = (*ByteBitSet)(nil)
+	_ json.Unmarshaler           = (*ByteBitSet)(nil)
+	_ driver.Valuer              = (*ByteBitSet)(nil)
+)
+
+// MarshalBinary encodes bbs as a BitOrder byte, a varint length prefix, and the raw mask
+// bytes, so that both trailing zero bytes and the BitOrder are preserved on round-trip.
+func (bbs ByteBitSet) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 1+binary.MaxVarintLen64+len(bbs.mask))
+	buf[0] = byte(bbs.order)
+	n := 1
+	n += binary.PutUvarint(buf[n:], uint64(len(bbs.mask)))
+	n += copy(buf[n:], bbs.mask)
+	return buf[:n], nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary, including the BitOrder it was
+// marshaled with.
+func (bbs *ByteBitSet) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidSourceString
+	}
+	order := BitOrder(data[0])
+	data = data[1:]
+
+	n, sz := binary.Uvarint(data)
+	if sz <= 0 {
+		return ErrInvalidSourceString
+	}
+
+	data = data[sz:]
+	if uint64(len(data)) < n {
+		return ErrInvalidSourceString
+	}
+
+	mask := make([]byte, n)
+	copy(mask, data[:n])
+	bbs.mask = mask
+	bbs.order = order
+	return nil
+}
+
+// MarshalText returns the hexadecimal string representation of bbs, as produced by String.
+// The string carries no BitOrder information; use MarshalBinary to round-trip BitOrder.
+func (bbs ByteBitSet) MarshalText() ([]byte, error) {
+	return []byte(bbs.String()), nil
+}
+
+// UnmarshalText parses the hexadecimal string representation produced by MarshalText.
+// The hex string carries no BitOrder information, so the receiver's existing BitOrder is
+// kept (MSBFirst for a zero-value ByteBitSet); use MarshalBinary/UnmarshalBinary to round-trip
+// a non-default BitOrder.
+func (bbs *ByteBitSet) UnmarshalText(text []byte) error {
+	parsed, err := ParseHexBytes(text, bbs.order)
+	if err != nil {
+		return err
+	}
+	*bbs = parsed
+	return nil
+}
+
+// MarshalJSON encodes bbs as a quoted hexadecimal string. Like MarshalText, it carries no
+// BitOrder information.
+func (bbs ByteBitSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bbs.String())
+}
+
+// UnmarshalJSON decodes a quoted hexadecimal string produced by MarshalJSON.
+// The receiver's existing BitOrder is kept, as the JSON string carries none of its own.
+func (bbs *ByteBitSet) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseHexString(s, bbs.order)
+	if err != nil {
+		return err
+	}
+	*bbs = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, encoding bbs as its hexadecimal string representation.
+// Like MarshalText, the stored value carries no BitOrder information.
+func (bbs ByteBitSet) Value() (driver.Value, error) {
+	return bbs.String(), nil
+}
+
+// Scan implements sql.Scanner, accepting a hexadecimal representation as either []byte or string.
+// The receiver's existing BitOrder is kept, as the stored value carries none of its own.
+func (bbs *ByteBitSet) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*bbs = ByteBitSet{order: bbs.order}
+		return nil
+	case []byte:
+		parsed, err := ParseHexBytes(v, bbs.order)
+		if err != nil {
+			return err
+		}
+		*bbs = parsed
+		return nil
+	case string:
+		parsed, err := ParseHexString(v, bbs.order)
+		if err != nil {
+			return err
+		}
+		*bbs = parsed
+		return nil
+	default:
+		return fmt.Errorf("bitset: unsupported Scan source type %T", src)
+	}
+}
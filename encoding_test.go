@@ -0,0 +1,180 @@
+package bitset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+type withBitSet struct {
+	Name  string
+	Flags ByteBitSet
+}
+
+func TestByteBitSet_JSONRoundTrip(t *testing.T) {
+	src := withBitSet{Name: "alice"}
+	src.Flags.Set(true, 0, 6, 20)
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var dst withBitSet
+	if err := json.Unmarshal(data, &dst); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if !Equal(src.Flags, dst.Flags) {
+		t.Errorf("expected %s, got %s", src.Flags.String(), dst.Flags.String())
+	}
+}
+
+func TestByteBitSet_GobRoundTrip(t *testing.T) {
+	src := withBitSet{Name: "bob"}
+	src.Flags.Set(true, 1, 9, 31)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	var dst withBitSet
+	if err := gob.NewDecoder(&buf).Decode(&dst); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if !Equal(src.Flags, dst.Flags) {
+		t.Errorf("expected %s, got %s", src.Flags.String(), dst.Flags.String())
+	}
+}
+
+func TestByteBitSet_GobRoundTrip_PreservesBitOrder(t *testing.T) {
+	src := withBitSet{Name: "carol"}
+	src.Flags = New(8, LSBFirst)
+	src.Flags.Set(true, 1, 9, 31)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	var dst withBitSet
+	if err := gob.NewDecoder(&buf).Decode(&dst); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if dst.Flags.order != LSBFirst {
+		t.Fatalf("expected LSBFirst, got %v", dst.Flags.order)
+	}
+	if !Equal(src.Flags, dst.Flags) {
+		t.Errorf("expected %s, got %s", src.Flags.String(), dst.Flags.String())
+	}
+}
+
+func TestByteBitSet_MarshalBinary(t *testing.T) {
+	t.Run("preserves trailing zero bytes", func(t *testing.T) {
+		bs := New(24)
+		bs.Set(true, 0)
+
+		data, err := bs.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+
+		var dst ByteBitSet
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+
+		if dst.Len() != bs.Len() {
+			t.Errorf("expected length %d, got %d", bs.Len(), dst.Len())
+		}
+	})
+
+	t.Run("preserves BitOrder", func(t *testing.T) {
+		bs := New(8, LSBFirst)
+		bs.Set(true, 0, 7)
+
+		data, err := bs.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+
+		var dst ByteBitSet
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+
+		if dst.order != LSBFirst {
+			t.Fatalf("expected LSBFirst, got %v", dst.order)
+		}
+		if !Equal(bs, dst) || !dst.IsSet(0) || !dst.IsSet(7) {
+			t.Errorf("expected %s, got %s", bs.String(), dst.String())
+		}
+	})
+}
+
+func TestByteBitSet_MarshalText(t *testing.T) {
+	bs, _ := ParseHexString("b3")
+
+	text, err := bs.MarshalText()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var dst ByteBitSet
+	if err := dst.UnmarshalText(text); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if !Equal(bs, dst) {
+		t.Errorf("expected %s, got %s", bs.String(), dst.String())
+	}
+}
+
+func TestByteBitSet_Scan(t *testing.T) {
+	t.Run("from string", func(t *testing.T) {
+		var bs ByteBitSet
+		if err := bs.Scan("b3"); err != nil {
+			t.Fatalf("failed to scan: %v", err)
+		}
+		if bs.String() != "b3" {
+			t.Errorf("expected b3, got %s", bs.String())
+		}
+	})
+
+	t.Run("from []byte", func(t *testing.T) {
+		var bs ByteBitSet
+		if err := bs.Scan([]byte("b3")); err != nil {
+			t.Fatalf("failed to scan: %v", err)
+		}
+		if bs.String() != "b3" {
+			t.Errorf("expected b3, got %s", bs.String())
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		var bs ByteBitSet
+		if err := bs.Scan(42); err == nil {
+			t.Error("expected error for unsupported type")
+		}
+	})
+
+	t.Run("value round-trips through Value", func(t *testing.T) {
+		bs, _ := ParseHexString("b3")
+		v, err := bs.Value()
+		if err != nil {
+			t.Fatalf("failed to get value: %v", err)
+		}
+
+		var dst ByteBitSet
+		if err := dst.Scan(v); err != nil {
+			t.Fatalf("failed to scan: %v", err)
+		}
+		if !Equal(bs, dst) {
+			t.Errorf("expected %s, got %s", bs.String(), dst.String())
+		}
+	})
+}
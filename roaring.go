@@ -0,0 +1,410 @@
+package bitset
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// arrayMaxCardinality is the maximum number of positions an array container
+// may hold before it is converted to a bitmap container, and the cardinality
+// at or below which a bitmap container is converted back to an array.
+const arrayMaxCardinality = 4096
+
+// container holds the bits for a single 16-bit key range [key<<16, key<<16+0xFFFF].
+// Exactly one of array or bitmap is non-nil at any time.
+type container struct {
+	key    uint16
+	array  []uint16      // sorted set positions, used while cardinality <= arrayMaxCardinality
+	bitmap *[1024]uint64 // fixed 8 KiB bitmap, used while cardinality > arrayMaxCardinality
+}
+
+// RoaringBitSet is a BitSet implementation that stores bits as a sorted list of
+// 16-bit-keyed containers, each either a sorted array of set positions or a
+// fixed-size bitmap, making it suitable for very large, sparse bit sets.
+type RoaringBitSet struct {
+	containers []*container
+}
+
+var _ BitSet = (*RoaringBitSet)(nil)
+
+// NewSparse returns a new, empty RoaringBitSet.
+func NewSparse() RoaringBitSet {
+	return RoaringBitSet{}
+}
+
+// search returns the index of the container with the given key, and whether it was found.
+// If not found, the index is where a container with that key would be inserted.
+func (rb RoaringBitSet) search(key uint16) (int, bool) {
+	i := sort.Search(len(rb.containers), func(i int) bool { return rb.containers[i].key >= key })
+	if i < len(rb.containers) && rb.containers[i].key == key {
+		return i, true
+	}
+	return i, false
+}
+
+// Set updates the bits at the specified positions to the given value (true to set, false to clear).
+// Containers are created lazily and converted between array and bitmap representation as needed.
+func (rb *RoaringBitSet) Set(val bool, bitpos ...uint) {
+	for _, bit := range bitpos {
+		rb.set(val, bit)
+	}
+}
+
+func (rb *RoaringBitSet) set(val bool, bit uint) {
+	hi := uint16(bit >> 16)
+	lo := uint16(bit & 0xFFFF)
+
+	idx, found := rb.search(hi)
+	if !found {
+		if !val {
+			return
+		}
+		rb.containers = append(rb.containers, nil)
+		copy(rb.containers[idx+1:], rb.containers[idx:])
+		rb.containers[idx] = &container{key: hi}
+	}
+
+	rb.containers[idx].setBit(lo, val)
+}
+
+// IsSet returns true if the bit at the specified position is set to 1.
+func (rb RoaringBitSet) IsSet(bit uint) bool {
+	idx, found := rb.search(uint16(bit >> 16))
+	if !found {
+		return false
+	}
+	return rb.containers[idx].isSet(uint16(bit & 0xFFFF))
+}
+
+// AreSet checks whether all or any of the specified bits are set, depending on the rule provided.
+func (rb RoaringBitSet) AreSet(rule CompareRule, bits ...uint) bool {
+	if len(bits) == 0 {
+		return false
+	}
+
+	if rule == All {
+		for _, bit := range bits {
+			if !rb.IsSet(bit) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, bit := range bits {
+		if rb.IsSet(bit) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns one past the highest bit position addressable without allocating a new container.
+func (rb RoaringBitSet) Len() uint {
+	if len(rb.containers) == 0 {
+		return 0
+	}
+	last := rb.containers[len(rb.containers)-1]
+	return (uint(last.key) + 1) << 16
+}
+
+// Count returns the number of bits set to 1 across all containers.
+func (rb RoaringBitSet) Count() uint {
+	var n int
+	for _, c := range rb.containers {
+		n += c.cardinality()
+	}
+	return uint(n)
+}
+
+// String returns the hexadecimal string representation of the bit set, via ToDense.
+func (rb RoaringBitSet) String() string {
+	return rb.ToDense().String()
+}
+
+// BinaryString returns the binary string representation of the bit set, via ToDense.
+func (rb RoaringBitSet) BinaryString() string {
+	return rb.ToDense().BinaryString()
+}
+
+// Bytes returns the dense byte slice representation of the bit set, via ToDense.
+func (rb RoaringBitSet) Bytes() []byte {
+	return rb.ToDense().Bytes()
+}
+
+// ToDense converts rb into an equivalent ByteBitSet.
+func (rb RoaringBitSet) ToDense() ByteBitSet {
+	dst := New(int(rb.Len()))
+	for _, c := range rb.containers {
+		base := uint(c.key) << 16
+		if c.bitmap != nil {
+			for i, w := range c.bitmap {
+				for w != 0 {
+					b := bits.TrailingZeros64(w)
+					dst.set(true, base+uint(i*64+b))
+					w &= w - 1
+				}
+			}
+			continue
+		}
+		for _, v := range c.array {
+			dst.set(true, base+uint(v))
+		}
+	}
+	return dst
+}
+
+// FromDense converts src into an equivalent RoaringBitSet.
+func FromDense(src ByteBitSet) RoaringBitSet {
+	var rb RoaringBitSet
+	for pos := uint(0); pos < src.Len(); pos++ {
+		if src.IsSet(pos) {
+			rb.set(true, pos)
+		}
+	}
+	return rb
+}
+
+// Union returns a new RoaringBitSet holding the bitwise OR of rb and other, computed container-by-container.
+func (rb RoaringBitSet) Union(other RoaringBitSet) RoaringBitSet {
+	var result RoaringBitSet
+	i, j := 0, 0
+	for i < len(rb.containers) && j < len(other.containers) {
+		a, b := rb.containers[i], other.containers[j]
+		switch {
+		case a.key < b.key:
+			result.containers = append(result.containers, a.clone())
+			i++
+		case a.key > b.key:
+			result.containers = append(result.containers, b.clone())
+			j++
+		default:
+			result.containers = append(result.containers, unionContainers(a, b))
+			i++
+			j++
+		}
+	}
+	for ; i < len(rb.containers); i++ {
+		result.containers = append(result.containers, rb.containers[i].clone())
+	}
+	for ; j < len(other.containers); j++ {
+		result.containers = append(result.containers, other.containers[j].clone())
+	}
+	return result
+}
+
+// Intersection returns a new RoaringBitSet holding the bitwise AND of rb and other, computed container-by-container.
+func (rb RoaringBitSet) Intersection(other RoaringBitSet) RoaringBitSet {
+	var result RoaringBitSet
+	i, j := 0, 0
+	for i < len(rb.containers) && j < len(other.containers) {
+		a, b := rb.containers[i], other.containers[j]
+		switch {
+		case a.key < b.key:
+			i++
+		case a.key > b.key:
+			j++
+		default:
+			if c := intersectContainers(a, b); c.cardinality() > 0 {
+				result.containers = append(result.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func (c *container) cardinality() int {
+	if c.bitmap != nil {
+		n := 0
+		for _, w := range c.bitmap {
+			n += bits.OnesCount64(w)
+		}
+		return n
+	}
+	return len(c.array)
+}
+
+func (c *container) isSet(lo uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[lo/64]&(1<<(lo%64)) != 0
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= lo })
+	return i < len(c.array) && c.array[i] == lo
+}
+
+func (c *container) setBit(lo uint16, val bool) {
+	if c.bitmap != nil {
+		word, bit := lo/64, lo%64
+		if val {
+			c.bitmap[word] |= 1 << bit
+		} else {
+			c.bitmap[word] &^= 1 << bit
+			if c.cardinality() <= arrayMaxCardinality {
+				c.toArray()
+			}
+		}
+		return
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= lo })
+	if val {
+		if i < len(c.array) && c.array[i] == lo {
+			return
+		}
+		c.array = append(c.array, 0)
+		copy(c.array[i+1:], c.array[i:])
+		c.array[i] = lo
+		if len(c.array) > arrayMaxCardinality {
+			c.toBitmap()
+		}
+		return
+	}
+
+	if i < len(c.array) && c.array[i] == lo {
+		c.array = append(c.array[:i], c.array[i+1:]...)
+	}
+}
+
+func (c *container) toBitmap() {
+	var bm [1024]uint64
+	for _, v := range c.array {
+		bm[v/64] |= 1 << (v % 64)
+	}
+	c.bitmap = &bm
+	c.array = nil
+}
+
+func (c *container) toArray() {
+	arr := make([]uint16, 0, c.cardinality())
+	for i, w := range c.bitmap {
+		for w != 0 {
+			b := bits.TrailingZeros64(w)
+			arr = append(arr, uint16(i*64+b))
+			w &= w - 1
+		}
+	}
+	c.array = arr
+	c.bitmap = nil
+}
+
+func (c *container) clone() *container {
+	if c.bitmap != nil {
+		bm := *c.bitmap
+		return &container{key: c.key, bitmap: &bm}
+	}
+	arr := make([]uint16, len(c.array))
+	copy(arr, c.array)
+	return &container{key: c.key, array: arr}
+}
+
+func unionContainers(a, b *container) *container {
+	if a.bitmap == nil && b.bitmap == nil {
+		merged := mergeSortedUnique(a.array, b.array)
+		c := &container{key: a.key, array: merged}
+		if len(merged) > arrayMaxCardinality {
+			c.toBitmap()
+		}
+		return c
+	}
+
+	var bm [1024]uint64
+	if a.bitmap != nil {
+		bm = *a.bitmap
+	} else {
+		for _, v := range a.array {
+			bm[v/64] |= 1 << (v % 64)
+		}
+	}
+	if b.bitmap != nil {
+		for i, w := range b.bitmap {
+			bm[i] |= w
+		}
+	} else {
+		for _, v := range b.array {
+			bm[v/64] |= 1 << (v % 64)
+		}
+	}
+
+	c := &container{key: a.key, bitmap: &bm}
+	if c.cardinality() <= arrayMaxCardinality {
+		c.toArray()
+	}
+	return c
+}
+
+func intersectContainers(a, b *container) *container {
+	switch {
+	case a.bitmap == nil && b.bitmap == nil:
+		return &container{key: a.key, array: intersectSorted(a.array, b.array)}
+	case a.bitmap == nil:
+		return &container{key: a.key, array: filterByBitmap(a.array, b.bitmap)}
+	case b.bitmap == nil:
+		return &container{key: a.key, array: filterByBitmap(b.array, a.bitmap)}
+	default:
+		var bm [1024]uint64
+		for i := range bm {
+			bm[i] = a.bitmap[i] & b.bitmap[i]
+		}
+		c := &container{key: a.key, bitmap: &bm}
+		if c.cardinality() <= arrayMaxCardinality {
+			c.toArray()
+		}
+		return c
+	}
+}
+
+func mergeSortedUnique(a, b []uint16) []uint16 {
+	result := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+	result = append(result, b[j:]...)
+	return result
+}
+
+func intersectSorted(a, b []uint16) []uint16 {
+	limit := len(a)
+	if len(b) < limit {
+		limit = len(b)
+	}
+	result := make([]uint16, 0, limit)
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func filterByBitmap(arr []uint16, bm *[1024]uint64) []uint16 {
+	result := make([]uint16, 0, len(arr))
+	for _, v := range arr {
+		if bm[v/64]&(1<<(v%64)) != 0 {
+			result = append(result, v)
+		}
+	}
+	return result
+}
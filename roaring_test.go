@@ -0,0 +1,118 @@
+package bitset
+
+import "testing"
+
+func TestRoaringBitSet_SetAndIsSet(t *testing.T) {
+	rb := NewSparse()
+	rb.Set(true, 0, 5, 100000, 1<<20)
+
+	if !rb.IsSet(0) || !rb.IsSet(5) || !rb.IsSet(100000) || !rb.IsSet(1<<20) {
+		t.Error("expected set positions to be set")
+	}
+
+	if rb.IsSet(1) || rb.IsSet(100001) {
+		t.Error("expected untouched positions to be unset")
+	}
+
+	rb.Set(false, 5)
+	if rb.IsSet(5) {
+		t.Error("expected bit 5 to be cleared")
+	}
+}
+
+func TestRoaringBitSet_AreSet(t *testing.T) {
+	rb := NewSparse()
+	rb.Set(true, 1, 2, 3)
+
+	if !rb.AreSet(All, 1, 2, 3) {
+		t.Error("expected all bits to be set")
+	}
+	if rb.AreSet(All, 1, 4) {
+		t.Error("expected not all bits to be set")
+	}
+	if !rb.AreSet(Any, 4, 2) {
+		t.Error("expected at least one bit to be set")
+	}
+	if rb.AreSet(All) {
+		t.Error("expected no bits to be checked")
+	}
+}
+
+func TestRoaringBitSet_ArrayToBitmapConversion(t *testing.T) {
+	rb := NewSparse()
+	for i := uint(0); i < arrayMaxCardinality+10; i++ {
+		rb.Set(true, i)
+	}
+
+	c := rb.containers[0]
+	if c.bitmap == nil {
+		t.Fatal("expected container to have converted to a bitmap")
+	}
+	if c.cardinality() != arrayMaxCardinality+10 {
+		t.Errorf("expected cardinality %d, got %d", arrayMaxCardinality+10, c.cardinality())
+	}
+
+	for i := uint(0); i < 20; i++ {
+		rb.Set(false, i)
+	}
+	if c.bitmap != nil {
+		t.Error("expected container to have converted back to an array")
+	}
+}
+
+func TestRoaringBitSet_Count(t *testing.T) {
+	rb := NewSparse()
+	rb.Set(true, 0, 1, 70000, 1<<20, 1<<21)
+	if n := rb.Count(); n != 5 {
+		t.Errorf("expected count 5, got %d", n)
+	}
+}
+
+func TestRoaringBitSet_Union(t *testing.T) {
+	a := NewSparse()
+	a.Set(true, 1, 70000)
+
+	b := NewSparse()
+	b.Set(true, 2, 70000, 1<<20)
+
+	u := a.Union(b)
+	for _, bit := range []uint{1, 2, 70000, 1 << 20} {
+		if !u.IsSet(bit) {
+			t.Errorf("expected bit %d to be set in union", bit)
+		}
+	}
+	if u.Count() != 4 {
+		t.Errorf("expected count 4, got %d", u.Count())
+	}
+}
+
+func TestRoaringBitSet_Intersection(t *testing.T) {
+	a := NewSparse()
+	a.Set(true, 1, 2, 70000)
+
+	b := NewSparse()
+	b.Set(true, 2, 70000, 1<<20)
+
+	x := a.Intersection(b)
+	if !x.IsSet(2) || !x.IsSet(70000) {
+		t.Error("expected shared bits to be set")
+	}
+	if x.IsSet(1) || x.IsSet(1<<20) {
+		t.Error("expected non-shared bits to be unset")
+	}
+	if x.Count() != 2 {
+		t.Errorf("expected count 2, got %d", x.Count())
+	}
+}
+
+func TestRoaringBitSet_DenseRoundTrip(t *testing.T) {
+	dense := New(128)
+	dense.Set(true, 0, 6, 100, 127)
+
+	sparse := FromDense(dense)
+	back := sparse.ToDense()
+
+	if !Equal(dense, back) {
+		t.Errorf("expected %s, got %s", dense.String(), back.String())
+	}
+}